@@ -0,0 +1,81 @@
+package cmdlets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	fieldHealthCmd = &cobra.Command{
+		Use:   "health",
+		Short: "health prints a colorized summary of field/robot health probes",
+		Long:  fieldHealthCmdLongDocs,
+		Run:   fieldHealthCmdRun,
+	}
+
+	fieldHealthCmdLongDocs = `health polls the field server's /health endpoint and prints a
+colorized summary, so at-event staff don't have to scrape Prometheus
+to know what's broken.`
+)
+
+// probeResult mirrors probe.Result on the field server.
+type probeResult struct {
+	Name        string    `json:"name"`
+	Status      string    `json:"status"`
+	Detail      string    `json:"detail"`
+	LastChecked time.Time `json:"last_checked"`
+}
+
+func init() {
+	fieldCmd.AddCommand(fieldHealthCmd)
+}
+
+func fieldHealthCmdRun(c *cobra.Command, args []string) {
+	fAddr := os.Getenv("BEST_FIELD_ADDR")
+	if fAddr == "" {
+		fAddr = "localhost:8080"
+	}
+
+	r, err := http.Get("http://" + fAddr + "/health")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting health: %s\n", err)
+		os.Exit(2)
+	}
+	defer r.Body.Close()
+
+	results := map[string]probeResult{}
+	if err := json.NewDecoder(r.Body).Decode(&results); err != nil {
+		fmt.Fprintf(os.Stderr, "Error decoding health: %s\n", err)
+		os.Exit(2)
+	}
+
+	names := make([]string, 0, len(results))
+	for n := range results {
+		names = append(names, n)
+	}
+	sort.Strings(names)
+
+	for _, n := range names {
+		res := results[n]
+		fmt.Printf("%s  %-20s %s\n", statusBadge(res.Status), n, res.Detail)
+	}
+}
+
+func statusBadge(status string) string {
+	switch status {
+	case "ok":
+		return "\033[32mOK  \033[0m"
+	case "warn":
+		return "\033[33mWARN\033[0m"
+	case "fail":
+		return "\033[31mFAIL\033[0m"
+	default:
+		return status
+	}
+}
@@ -0,0 +1,212 @@
+package cmdlets
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"sort"
+	"strconv"
+
+	"github.com/spf13/cobra"
+)
+
+var (
+	fieldMapCmd = &cobra.Command{
+		Use:   "map",
+		Short: "map provides access to the history of field/team mappings",
+		Long:  fieldMapCmdLongDocs,
+	}
+
+	fieldMapHistoryCmd = &cobra.Command{
+		Use:   "history",
+		Short: "history lists every mapping that has been applied to the field",
+		Run:   fieldMapHistoryCmdRun,
+	}
+
+	fieldMapDiffCmd = &cobra.Command{
+		Use:   "diff <v1> <v2>",
+		Short: "diff compares two historical mappings quad by quad",
+		Args:  cobra.ExactArgs(2),
+		Run:   fieldMapDiffCmdRun,
+	}
+
+	fieldMapRollbackCmd = &cobra.Command{
+		Use:   "rollback <version>",
+		Short: "rollback restores a previous mapping as the current one",
+		Long:  fieldMapRollbackCmdLongDocs,
+		Args:  cobra.ExactArgs(1),
+		Run:   fieldMapRollbackCmdRun,
+	}
+
+	fieldMapCmdLongDocs = `map lets field staff inspect and recover from the versioned history
+of team/quadrant mappings kept by the field server.`
+
+	fieldMapRollbackCmdLongDocs = `rollback re-applies a previous mapping version as the current one.
+This is itself recorded as a new version in the history, so rollbacks
+are as auditable as any other remap.`
+)
+
+func init() {
+	fieldCmd.AddCommand(fieldMapCmd)
+	fieldMapCmd.AddCommand(fieldMapHistoryCmd)
+	fieldMapCmd.AddCommand(fieldMapDiffCmd)
+	fieldMapCmd.AddCommand(fieldMapRollbackCmd)
+}
+
+func fieldMapHistoryCmdRun(c *cobra.Command, args []string) {
+	fAddr := os.Getenv("BEST_FIELD_ADDR")
+	if fAddr == "" {
+		fAddr = "localhost:8080"
+	}
+
+	hist, err := fetchMapHistory(fAddr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting map history: %s\n", err)
+		os.Exit(2)
+	}
+
+	for _, snap := range hist {
+		fmt.Printf("v%d\t%s\t%s\t%s\n", snap.Version, snap.Timestamp.Format("2006-01-02 15:04:05"), snap.Operator, snap.Reason)
+	}
+}
+
+func fieldMapDiffCmdRun(c *cobra.Command, args []string) {
+	fAddr := os.Getenv("BEST_FIELD_ADDR")
+	if fAddr == "" {
+		fAddr = "localhost:8080"
+	}
+
+	v1, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid version %q: %s\n", args[0], err)
+		os.Exit(2)
+	}
+	v2, err := strconv.Atoi(args[1])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid version %q: %s\n", args[1], err)
+		os.Exit(2)
+	}
+
+	a, err := fetchMapVersion(fAddr, v1)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting version %d: %s\n", v1, err)
+		os.Exit(2)
+	}
+	b, err := fetchMapVersion(fAddr, v2)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting version %d: %s\n", v2, err)
+		os.Exit(2)
+	}
+
+	quads := make(map[string]struct{})
+	for _, q := range a.Mapping {
+		quads[q] = struct{}{}
+	}
+	for _, q := range b.Mapping {
+		quads[q] = struct{}{}
+	}
+	sorted := make([]string, 0, len(quads))
+	for q := range quads {
+		sorted = append(sorted, q)
+	}
+	sort.Strings(sorted)
+
+	aByQuad := invertMapping(a.Mapping)
+	bByQuad := invertMapping(b.Mapping)
+
+	changed := false
+	for _, quad := range sorted {
+		oldTeam, newTeam := aByQuad[quad], bByQuad[quad]
+		if oldTeam == newTeam {
+			continue
+		}
+		changed = true
+		fmt.Printf("  %s:\t%s -> %s\n", quad, blank(oldTeam), blank(newTeam))
+	}
+	if !changed {
+		fmt.Printf("v%d and v%d are identical\n", v1, v2)
+	}
+}
+
+func fieldMapRollbackCmdRun(c *cobra.Command, args []string) {
+	fAddr := os.Getenv("BEST_FIELD_ADDR")
+	if fAddr == "" {
+		fAddr = "localhost:8080"
+	}
+
+	target, err := strconv.Atoi(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Invalid version %q: %s\n", args[0], err)
+		os.Exit(2)
+	}
+
+	old, err := fetchMapVersion(fAddr, target)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting version %d: %s\n", target, err)
+		os.Exit(2)
+	}
+
+	cur, err := fetchCurrentMap(fAddr)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error getting current map: %s\n", err)
+		os.Exit(2)
+	}
+
+	operator, reason, err := promptMapMetadata()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error polling for audit info: %s\n", err)
+		os.Exit(2)
+	}
+	if reason == "" {
+		reason = fmt.Sprintf("rollback to v%d", target)
+	}
+
+	if err := swapMap(fAddr, cur.Version, old.Mapping, operator, reason); err != nil {
+		fmt.Fprintf(os.Stderr, "Error applying rollback: %s\n", err)
+		os.Exit(2)
+	}
+}
+
+func fetchMapHistory(fAddr string) ([]mapSnapshot, error) {
+	r, err := http.Get("http://" + fAddr + "/admin/map/history")
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	hist := []mapSnapshot{}
+	if err := json.NewDecoder(r.Body).Decode(&hist); err != nil {
+		return nil, err
+	}
+	return hist, nil
+}
+
+func fetchMapVersion(fAddr string, version int) (*mapSnapshot, error) {
+	r, err := http.Get(fmt.Sprintf("http://%s/admin/map/version/%d", fAddr, version))
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	snap := new(mapSnapshot)
+	if err := json.NewDecoder(r.Body).Decode(snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+func invertMapping(teamToQuad map[string]string) map[string]string {
+	quadToTeam := make(map[string]string, len(teamToQuad))
+	for team, quad := range teamToQuad {
+		quadToTeam[quad] = team
+	}
+	return quadToTeam
+}
+
+func blank(s string) string {
+	if s == "" {
+		return "(empty)"
+	}
+	return s
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"os"
 	"strconv"
+	"time"
 
 	"github.com/AlecAivazis/survey/v2"
 	"github.com/spf13/cobra"
@@ -22,9 +23,36 @@ var (
 	}
 
 	fieldRemapCmdLongDocs = `remap is used to insert an immediate update to the field/team mapping
-table.  This will disrupt any teams currently on the field!`
+table.  This will disrupt any teams currently on the field!
+
+Every remap is appended to a versioned history on the field server, so a
+bad remap can be recovered from with "field map rollback" instead of
+re-typing every quad.`
 )
 
+// mapSnapshot is the versioned view of the team/quadrant mapping as
+// served by /admin/map/current and /admin/map/version/{n}.  The
+// version number is used for optimistic concurrency when swapping in
+// a new mapping.
+type mapSnapshot struct {
+	Version   int               `json:"version"`
+	Mapping   map[string]string `json:"mapping"`
+	Operator  string            `json:"operator"`
+	Reason    string            `json:"reason"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// mapSwapRequest is POSTed to /admin/map/swap.  The version it is
+// based on goes in the ?from= query parameter, not in this body; it
+// must match the server's current version or the swap is rejected
+// with 409 Conflict so two remap operators can't clobber each other
+// mid-match.
+type mapSwapRequest struct {
+	Mapping  map[string]string `json:"mapping"`
+	Operator string            `json:"operator"`
+	Reason   string            `json:"reason"`
+}
+
 func init() {
 	fieldCmd.AddCommand(fieldRemapCmd)
 }
@@ -49,24 +77,16 @@ func fieldRemapCmdRun(c *cobra.Command, args []string) {
 	}
 	r.Body.Close()
 
-	r, err = http.Get("http://" + fAddr + "/admin/map/current")
+	cur, err := fetchCurrentMap(fAddr)
 	if err != nil {
 		fmt.Fprintf(os.Stderr, "Error getting map: %s\n", err)
 		os.Exit(2)
 	}
 
-	cMap := make(map[string]string)
-	dec = json.NewDecoder(r.Body)
-	if err := dec.Decode(&cMap); err != nil {
-		fmt.Fprintf(os.Stderr, "Error getting map: %s\n", err)
-		os.Exit(2)
-	}
-	ccMap := make(map[string]string, len(cMap))
-	r.Body.Close()
-
-	if len(cMap) > 0 {
+	ccMap := make(map[string]string, len(cur.Mapping))
+	if len(cur.Mapping) > 0 {
 		fmt.Println("Current Mapping:")
-		for team, quad := range cMap {
+		for team, quad := range cur.Mapping {
 			fmt.Printf("  %s:\t%s\n", quad, team)
 			ccMap[quad] = team
 		}
@@ -104,7 +124,86 @@ func fieldRemapCmdRun(c *cobra.Command, args []string) {
 		nnMap[t.(string)] = f
 	}
 
+	operator, reason, err := promptMapMetadata()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error polling for audit info: %s\n", err)
+		os.Exit(2)
+	}
+
+	if err := swapMap(fAddr, cur.Version, nnMap, operator, reason); err != nil {
+		fmt.Fprintf(os.Stderr, "Error applying map: %s\n", err)
+		os.Exit(2)
+	}
+}
+
+// promptMapMetadata asks the operator who they are and why they're
+// making this change, so the append-only history log has an audit
+// trail to go with every mapping.
+func promptMapMetadata() (operator, reason string, err error) {
+	meta := struct {
+		Operator string
+		Reason   string
+	}{}
+	q := []*survey.Question{
+		{
+			Name:     "operator",
+			Validate: survey.Required,
+			Prompt:   &survey.Input{Message: "Your name"},
+		},
+		{
+			Name:     "reason",
+			Validate: survey.Required,
+			Prompt:   &survey.Input{Message: "Reason for this remap"},
+		},
+	}
+	if err := survey.Ask(q, &meta); err != nil {
+		return "", "", err
+	}
+	return meta.Operator, meta.Reason, nil
+}
+
+// fetchCurrentMap fetches the current versioned mapping from the
+// field server.
+func fetchCurrentMap(fAddr string) (*mapSnapshot, error) {
+	r, err := http.Get("http://" + fAddr + "/admin/map/current")
+	if err != nil {
+		return nil, err
+	}
+	defer r.Body.Close()
+
+	snap := new(mapSnapshot)
+	if err := json.NewDecoder(r.Body).Decode(snap); err != nil {
+		return nil, err
+	}
+	return snap, nil
+}
+
+// swapMap performs the atomic swap, rejecting the change on the
+// client side if the server reports a conflict because someone else
+// remapped the field first.
+func swapMap(fAddr string, from int, mapping map[string]string, operator, reason string) error {
 	buf := new(bytes.Buffer)
-	json.NewEncoder(buf).Encode(nnMap)
-	http.Post("http://"+fAddr+"/admin/map/immediate", "application/json", buf)
+	if err := json.NewEncoder(buf).Encode(mapSwapRequest{
+		Mapping:  mapping,
+		Operator: operator,
+		Reason:   reason,
+	}); err != nil {
+		return err
+	}
+
+	url := fmt.Sprintf("http://%s/admin/map/swap?from=%d", fAddr, from)
+	resp, err := http.Post(url, "application/json", buf)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return nil
+	case http.StatusConflict:
+		return errors.New("map was changed by another operator, re-run to pick up the latest version")
+	default:
+		return fmt.Errorf("server rejected swap: %s", resp.Status)
+	}
 }
@@ -0,0 +1,127 @@
+package cmdlets
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/BESTRobotics/gizmo/pkg/events"
+	"github.com/BESTRobotics/gizmo/pkg/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/expfmt"
+	"github.com/spf13/cobra"
+)
+
+var (
+	fieldReplayCmd = &cobra.Command{
+		Use:   "replay <file>",
+		Short: "replay re-feeds a recorded event log through the metrics collectors",
+		Long:  fieldReplayCmdLongDocs,
+		Args:  cobra.ExactArgs(1),
+		Run:   fieldReplayCmdRun,
+	}
+
+	fieldReplayCmdLongDocs = `replay reads a newline-delimited JSON event log, of the kind written
+by the field server's event bus file sink, and re-feeds every
+"robot.report" event through the same Prometheus collectors used
+live, so a disputed match can be re-analyzed offline.`
+)
+
+// replayedReport mirrors the unexported report type that metrics
+// decodes off the wire, so replayed "robot.report" event payloads
+// can be unmarshaled the same way.
+type replayedReport struct {
+	VBat              int32
+	WatchdogRemaining int32
+	WatchdogOK        bool
+	RSSI              uint8
+	PwrBoard          bool
+	PwrPico           bool
+	PwrGPIO           bool
+	PwrMainA          bool
+	PwrMainB          bool
+}
+
+func init() {
+	fieldCmd.AddCommand(fieldReplayCmd)
+}
+
+func fieldReplayCmdRun(c *cobra.Command, args []string) {
+	f, err := os.Open(args[0])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error opening event log: %s\n", err)
+		os.Exit(2)
+	}
+	defer f.Close()
+
+	reg := prometheus.NewRegistry()
+	coll := metrics.NewCollector(reg)
+
+	n, err := replayReports(f, coll)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error reading event log: %s\n", err)
+		os.Exit(2)
+	}
+	fmt.Printf("Replayed %d robot.report event(s)\n\n", n)
+
+	mfs, err := reg.Gather()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error gathering replayed metrics: %s\n", err)
+		os.Exit(2)
+	}
+
+	enc := expfmt.NewEncoder(os.Stdout, expfmt.NewFormat(expfmt.TypeTextPlain))
+	for _, mf := range mfs {
+		if err := enc.Encode(mf); err != nil {
+			fmt.Fprintf(os.Stderr, "Error encoding metrics: %s\n", err)
+			os.Exit(2)
+		}
+	}
+}
+
+// replayReports scans a newline-delimited JSON event log from r,
+// applying every "robot.report" event to coll, and returns how many
+// it applied. Events of any other type, and lines that fail to
+// parse, are skipped rather than aborting the whole replay.
+func replayReports(r *os.File, coll *metrics.Collector) (int, error) {
+	n := 0
+	scn := bufio.NewScanner(r)
+	for scn.Scan() {
+		var ev events.Event
+		if err := json.Unmarshal(scn.Bytes(), &ev); err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping corrupt event: %s\n", err)
+			continue
+		}
+		if ev.Type != "robot.report" {
+			continue
+		}
+
+		var rpt replayedReport
+		if err := json.Unmarshal(ev.Data, &rpt); err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping unreadable robot.report: %s\n", err)
+			continue
+		}
+
+		team := ev.Labels.Team
+		coll.RobotRSSI.With(prometheus.Labels{"team": team}).Set(float64(rpt.RSSI))
+		coll.RobotVBat.With(prometheus.Labels{"team": team}).Set(float64(rpt.VBat))
+		coll.RobotWatchdogLifetime.With(prometheus.Labels{"team": team}).Set(float64(rpt.WatchdogRemaining))
+		coll.RobotPowerBoard.With(prometheus.Labels{"team": team}).Set(boolToFloat(rpt.PwrBoard))
+		coll.RobotPowerPico.With(prometheus.Labels{"team": team}).Set(boolToFloat(rpt.PwrPico))
+		coll.RobotPowerGPIO.With(prometheus.Labels{"team": team}).Set(boolToFloat(rpt.PwrGPIO))
+		coll.RobotPowerBusA.With(prometheus.Labels{"team": team}).Set(boolToFloat(rpt.PwrMainA))
+		coll.RobotPowerBusB.With(prometheus.Labels{"team": team}).Set(boolToFloat(rpt.PwrMainB))
+		coll.RobotWatchdogOK.With(prometheus.Labels{"team": team}).Set(boolToFloat(rpt.WatchdogOK))
+		coll.RobotLastReportTimestamp.With(prometheus.Labels{"team": team}).Set(float64(ev.Timestamp.Unix()))
+		n++
+	}
+	return n, scn.Err()
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
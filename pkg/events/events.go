@@ -0,0 +1,99 @@
+// Package events provides a small in-process publish/subscribe bus
+// that components use to record what actually happened on the
+// field, so a disputed match can be reconstructed after the fact.
+package events
+
+import (
+	"encoding/json"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Labels identify what an Event is about. Any of these may be left
+// blank when they don't apply, e.g. a remap event has no Team.
+type Labels struct {
+	Field string `json:"field,omitempty"`
+	Quad  string `json:"quad,omitempty"`
+	Team  string `json:"team,omitempty"`
+}
+
+// Event is a single thing that happened, with enough context to
+// reconstruct it later. Seq is monotonic and unique per Bus, so
+// events can be ordered and deduplicated even if their Timestamps
+// collide.
+type Event struct {
+	Seq       uint64          `json:"seq"`
+	Type      string          `json:"type"`
+	Timestamp time.Time       `json:"timestamp"`
+	Labels    Labels          `json:"labels,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// Subscriber receives every Event published to a Bus. Subscribers
+// are called synchronously, in no particular order (Bus.Publish
+// ranges over a map), so they must not block — and must not call
+// Subscribe or an unsubscribe func of their own, which would
+// deadlock on Bus.mu.
+type Subscriber func(Event)
+
+// Bus fans a stream of Events out to every Subscriber. The zero
+// value is not usable; use NewBus.
+type Bus struct {
+	seq uint64
+
+	mu     sync.RWMutex
+	nextID uint64
+	subs   map[uint64]Subscriber
+}
+
+// NewBus returns a ready-to-use event Bus.
+func NewBus() *Bus {
+	return &Bus{subs: make(map[uint64]Subscriber)}
+}
+
+// Subscribe registers s to receive every future Event. The returned
+// func removes s; callers that subscribe for the lifetime of a
+// single request (e.g. a WebSocket stream) must call it when done,
+// or the subscription leaks for the life of the Bus.
+func (b *Bus) Subscribe(s Subscriber) (unsubscribe func()) {
+	b.mu.Lock()
+	id := b.nextID
+	b.nextID++
+	b.subs[id] = s
+	b.mu.Unlock()
+
+	return func() {
+		b.mu.Lock()
+		delete(b.subs, id)
+		b.mu.Unlock()
+	}
+}
+
+// Publish builds an Event from typ, labels and data, assigns it the
+// next sequence number, and fans it out to every subscriber while
+// holding b.mu for reading. data is marshaled to JSON; pass nil if
+// there's nothing more to record.
+func (b *Bus) Publish(typ string, labels Labels, data interface{}) Event {
+	var raw json.RawMessage
+	if data != nil {
+		if enc, err := json.Marshal(data); err == nil {
+			raw = enc
+		}
+	}
+
+	ev := Event{
+		Seq:       atomic.AddUint64(&b.seq, 1),
+		Type:      typ,
+		Timestamp: time.Now(),
+		Labels:    labels,
+		Data:      raw,
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for _, s := range b.subs {
+		s(ev)
+	}
+	return ev
+}
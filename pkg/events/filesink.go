@@ -0,0 +1,114 @@
+package events
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+// defaultMaxBytes is the size at which a FileSink rotates its
+// current log file before it grows large enough to make replay
+// unwieldy.
+const defaultMaxBytes = 64 * 1024 * 1024
+
+// FileSink writes every Event it receives to path as newline
+// delimited JSON, rotating to a timestamped sibling file once path
+// grows past MaxBytes.
+type FileSink struct {
+	path     string
+	MaxBytes int64
+
+	l hclog.Logger
+
+	mu   sync.Mutex
+	f    *os.File
+	size int64
+}
+
+// NewFileSink opens (creating if necessary) the log at path for
+// appending. Call Write, or pass the sink's Write method to
+// Bus.Subscribe, to record events. l is used to surface failures on
+// the write/rotate path, since this log is the durable audit trail a
+// disputed match gets reconstructed from, and Write itself can't
+// return an error to its caller.
+func NewFileSink(path string, l hclog.Logger) (*FileSink, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+	info, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+
+	return &FileSink{
+		path:     path,
+		MaxBytes: defaultMaxBytes,
+		l:        l,
+		f:        f,
+		size:     info.Size(),
+	}, nil
+}
+
+// Write appends ev to the log, rotating first if it would push the
+// current file past MaxBytes. It is safe to pass directly to
+// Bus.Subscribe. A marshal, rotate, or write failure is logged and
+// drops ev rather than blocking the bus or panicking; callers that
+// need to know the log is no longer being written to should watch
+// for these warnings.
+func (s *FileSink) Write(ev Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	line, err := json.Marshal(ev)
+	if err != nil {
+		s.l.Warn("Failed to marshal event for file sink, dropping it", "seq", ev.Seq, "error", err)
+		return
+	}
+	line = append(line, '\n')
+
+	if s.size+int64(len(line)) > s.MaxBytes {
+		if err := s.rotate(); err != nil {
+			s.l.Warn("Failed to rotate event log, dropping event", "path", s.path, "error", err)
+			return
+		}
+	}
+
+	n, err := s.f.Write(line)
+	if err != nil {
+		s.l.Warn("Failed to write event to log", "path", s.path, "error", err)
+		return
+	}
+	s.size += int64(n)
+}
+
+// rotate renames the current log aside with a timestamp suffix and
+// opens a fresh one at the original path. Callers must hold s.mu.
+func (s *FileSink) rotate() error {
+	s.f.Close()
+
+	rotated := fmt.Sprintf("%s.%d", s.path, time.Now().Unix())
+	if err := os.Rename(s.path, rotated); err != nil {
+		return err
+	}
+
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0644)
+	if err != nil {
+		return err
+	}
+	s.f = f
+	s.size = 0
+	return nil
+}
+
+// Close closes the underlying log file.
+func (s *FileSink) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.f.Close()
+}
@@ -0,0 +1,56 @@
+package events
+
+import (
+	"sync"
+	"time"
+)
+
+// defaultRingCapacity bounds memory use for the in-memory event
+// buffer backing /admin/events; older events simply fall off the
+// front once it fills. The file sink is the durable record.
+const defaultRingCapacity = 4096
+
+// RingBuffer keeps the most recent Events in memory for cheap
+// access, e.g. to serve /admin/events without hitting disk.
+type RingBuffer struct {
+	capacity int
+
+	mu     sync.RWMutex
+	events []Event
+}
+
+// NewRingBuffer returns a RingBuffer holding at most capacity
+// events. Pass its Add method to Bus.Subscribe to keep it current.
+func NewRingBuffer(capacity int) *RingBuffer {
+	if capacity <= 0 {
+		capacity = defaultRingCapacity
+	}
+	return &RingBuffer{capacity: capacity}
+}
+
+// Add appends ev to the buffer, dropping the oldest event if the
+// buffer is full. It is safe to pass directly to Bus.Subscribe.
+func (r *RingBuffer) Add(ev Event) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.events = append(r.events, ev)
+	if over := len(r.events) - r.capacity; over > 0 {
+		r.events = r.events[over:]
+	}
+}
+
+// Since returns every buffered event with a Timestamp strictly after
+// since, oldest first.
+func (r *RingBuffer) Since(since time.Time) []Event {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	out := make([]Event, 0, len(r.events))
+	for _, ev := range r.events {
+		if ev.Timestamp.After(since) {
+			out = append(out, ev)
+		}
+	}
+	return out
+}
@@ -0,0 +1,36 @@
+package events
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRingBufferEviction(t *testing.T) {
+	r := NewRingBuffer(2)
+
+	base := time.Unix(1700000000, 0)
+	r.Add(Event{Seq: 1, Timestamp: base})
+	r.Add(Event{Seq: 2, Timestamp: base.Add(time.Second)})
+	r.Add(Event{Seq: 3, Timestamp: base.Add(2 * time.Second)})
+
+	got := r.Since(time.Time{})
+	if len(got) != 2 {
+		t.Fatalf("expected 2 buffered events after eviction, got %d", len(got))
+	}
+	if got[0].Seq != 2 || got[1].Seq != 3 {
+		t.Fatalf("expected seqs [2 3], got [%d %d]", got[0].Seq, got[1].Seq)
+	}
+}
+
+func TestRingBufferSince(t *testing.T) {
+	r := NewRingBuffer(0)
+
+	base := time.Unix(1700000000, 0)
+	r.Add(Event{Seq: 1, Timestamp: base})
+	r.Add(Event{Seq: 2, Timestamp: base.Add(time.Minute)})
+
+	got := r.Since(base)
+	if len(got) != 1 || got[0].Seq != 2 {
+		t.Fatalf("Since(base) = %+v, want only seq 2", got)
+	}
+}
@@ -3,8 +3,10 @@ package gamepad
 import (
 	"errors"
 	"sync"
+	"time"
 
 	"github.com/0xcafed00d/joystick"
+	"github.com/BESTRobotics/gizmo/pkg/events"
 	"github.com/hashicorp/go-hclog"
 )
 
@@ -37,12 +39,22 @@ type Values struct {
 	ButtonRT         bool
 }
 
-// JSController handles the action of actually fetching data from the
-// joystick and making it available to the rest of the system.
+// JSController handles the action of actually fetching data from a
+// gamepad Source and making it available to the rest of the system.
 type JSController struct {
 	l hclog.Logger
 
-	controllers map[string]joystick.Joystick
+	controllers map[string]Source
+	lastSeen    map[string]time.Time
+
+	// registeredProbes tracks which fields already have a
+	// "gamepad-<field>" probe registered, so rebinding a field (e.g.
+	// swapping a dead joystick, or switching a field from local USB
+	// to MQTT mid-event) doesn't try to register the same probe name
+	// twice.
+	registeredProbes map[string]bool
+
+	events *events.Bus
 
 	cMutex sync.RWMutex
 }
@@ -50,8 +62,10 @@ type JSController struct {
 // NewJSController sets up the joystick controller.
 func NewJSController(opts ...Option) JSController {
 	jsc := JSController{
-		l:           hclog.NewNullLogger(),
-		controllers: make(map[string]joystick.Joystick),
+		l:                hclog.NewNullLogger(),
+		controllers:      make(map[string]Source),
+		lastSeen:         make(map[string]time.Time),
+		registeredProbes: make(map[string]bool),
 	}
 
 	for _, o := range opts {
@@ -60,7 +74,7 @@ func NewJSController(opts ...Option) JSController {
 	return jsc
 }
 
-// BindController attaches a controller to a particular name.
+// BindController attaches a local USB joystick to a particular name.
 func (j *JSController) BindController(name string, id int) error {
 	j.cMutex.Lock()
 	defer j.cMutex.Unlock()
@@ -68,59 +82,65 @@ func (j *JSController) BindController(name string, id int) error {
 	if jserr != nil {
 		return jserr
 	}
-	j.controllers[name] = js
 
 	if js.AxisCount() != 6 || js.ButtonCount() != 12 {
 		j.l.Error("Wrong joystick counts!", "axis", js.AxisCount(), " buttons", js.ButtonCount())
 		return errors.New("bad joystick config")
 	}
+	j.controllers[name] = &localJSSource{js: js}
 
 	j.l.Info("Successfully bound controller", "fid", name, "jsid", id)
+	j.RegisterProbe(name)
+	j.publish("gamepad.bind", name, map[string]interface{}{"jsid": id})
+	return nil
+}
+
+// UnbindController detaches whatever controller is currently bound
+// to name, if any.
+func (j *JSController) UnbindController(name string) error {
+	j.cMutex.Lock()
+	defer j.cMutex.Unlock()
+
+	if _, ok := j.controllers[name]; !ok {
+		return ErrNoSuchField
+	}
+	delete(j.controllers, name)
+	delete(j.lastSeen, name)
+
+	j.l.Info("Unbound controller", "fid", name)
+	j.publish("gamepad.unbind", name, nil)
 	return nil
 }
 
-// GetState polls the joystick and updates the values available to the
-// controller.
+// publish records a gamepad event on the bus, if one is configured.
+func (j *JSController) publish(typ, field string, data interface{}) {
+	if j.events == nil {
+		return
+	}
+	j.events.Publish(typ, events.Labels{Field: field}, data)
+}
+
+// GetState polls the bound Source and returns the values available to
+// the controller.
 func (j *JSController) GetState(fieldID string) (*Values, error) {
 	j.cMutex.RLock()
-	defer j.cMutex.RUnlock()
-
-	js, ok := j.controllers[fieldID]
+	src, ok := j.controllers[fieldID]
+	j.cMutex.RUnlock()
 	if !ok {
 		return nil, ErrNoSuchField
 	}
 
-	jinfo, err := js.Read()
+	jvals, err := src.Read()
 	if err != nil {
 		return nil, err
 	}
 
-	jvals := Values{
-		AxisLX: mapRange(jinfo.AxisData[0], -32768, 32768, 0, 255),
-		AxisLY: mapRange(jinfo.AxisData[1], -32768, 32768, 0, 255),
-
-		AxisRX: mapRange(jinfo.AxisData[2], -32768, 32768, 0, 255),
-		AxisRY: mapRange(jinfo.AxisData[3], -32768, 32768, 0, 255),
-
-		AxisDX: mapRange(jinfo.AxisData[4], -32768, 32768, 0, 255),
-		AxisDY: mapRange(jinfo.AxisData[5], -32768, 32768, 0, 255),
-
-		ButtonBack:       (jinfo.Buttons & (1 << uint32(8))) != 0,
-		ButtonStart:      (jinfo.Buttons & (1 << uint32(9))) != 0,
-		ButtonLeftStick:  (jinfo.Buttons & (1 << uint32(10))) != 0,
-		ButtonRightStick: (jinfo.Buttons & (1 << uint32(11))) != 0,
-		ButtonX:          (jinfo.Buttons & (1 << uint32(0))) != 0,
-		ButtonY:          (jinfo.Buttons & (1 << uint32(3))) != 0,
-		ButtonA:          (jinfo.Buttons & (1 << uint32(1))) != 0,
-		ButtonB:          (jinfo.Buttons & (1 << uint32(2))) != 0,
-		ButtonLShoulder:  (jinfo.Buttons & (1 << uint32(4))) != 0,
-		ButtonRShoulder:  (jinfo.Buttons & (1 << uint32(5))) != 0,
-		ButtonLT:         (jinfo.Buttons & (1 << uint32(6))) != 0,
-		ButtonRT:         (jinfo.Buttons & (1 << uint32(7))) != 0,
-	}
+	j.cMutex.Lock()
+	j.lastSeen[fieldID] = time.Now()
+	j.cMutex.Unlock()
 
 	j.l.Trace("Refreshed state", "fid", fieldID, "state", jvals)
-	return &jvals, nil
+	return jvals, nil
 }
 
 // func (j *JSController) doRefreshAll() {
@@ -136,7 +156,3 @@ func (j *JSController) GetState(fieldID string) (*Values, error) {
 // 		}()
 // 	}
 // }
-
-func mapRange(x, xMin, xMax, oMin, oMax int) int {
-	return (x-xMin)*(oMax-oMin)/(xMax-xMin) + oMin
-}
@@ -0,0 +1,104 @@
+package gamepad
+
+import (
+	"encoding/json"
+	"sync"
+	"time"
+
+	mqtt "github.com/eclipse/paho.mqtt.golang"
+	"github.com/hashicorp/go-hclog"
+)
+
+// staleAfter is how long an MQTT gamepad source can go without a
+// message before Read falls back to a safe, all-released state.
+// Publishers are expected to send at ~50Hz, so this is many missed
+// frames, not transient jitter.
+const staleAfter = 250 * time.Millisecond
+
+// axisNeutral is the stick-centered value on the 0-255 scale that
+// localJSSource.Read maps raw axis data onto, i.e. mapRange(0,
+// -32768, 32768, 0, 255). The stale-fallback Values must use this,
+// not the zero value, or "safe" would mean hard-over on every axis
+// instead of neutral.
+const axisNeutral = 127
+
+// mqttSource subscribes to a topic carrying JSON-encoded Values
+// messages, so a physical controller on a remote driver station can
+// feed the field without USB. Publishers are expected to set a
+// last-will on the topic marking themselves offline; either way, if
+// no message has arrived within staleAfter, Read returns a
+// centered-axis, all-released Values as a safety fallback, similar
+// in spirit to the watchdog already tracked in metrics.
+type mqttSource struct {
+	l hclog.Logger
+
+	mu       sync.RWMutex
+	last     Values
+	lastSeen time.Time
+}
+
+// newMQTTSource subscribes to topic on client and returns a Source
+// that serves the most recently received Values.
+func newMQTTSource(client mqtt.Client, topic string, l hclog.Logger) (*mqttSource, error) {
+	s := &mqttSource{l: l}
+
+	if tok := client.Subscribe(topic, 1, s.handleMessage); tok.Wait() && tok.Error() != nil {
+		return nil, tok.Error()
+	}
+	return s, nil
+}
+
+func (s *mqttSource) handleMessage(c mqtt.Client, msg mqtt.Message) {
+	var v Values
+	if err := json.Unmarshal(msg.Payload(), &v); err != nil {
+		s.l.Warn("Bad gamepad values message", "topic", msg.Topic(), "error", err)
+		return
+	}
+
+	s.mu.Lock()
+	s.last = v
+	s.lastSeen = time.Now()
+	s.mu.Unlock()
+}
+
+// Read returns the most recently received Values, or a safety
+// fallback Values with centered axes and all buttons released if the
+// last message is older than staleAfter.
+func (s *mqttSource) Read() (*Values, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	if time.Since(s.lastSeen) > staleAfter {
+		return &Values{
+			AxisLX: axisNeutral,
+			AxisLY: axisNeutral,
+			AxisRX: axisNeutral,
+			AxisRY: axisNeutral,
+			AxisDX: axisNeutral,
+			AxisDY: axisNeutral,
+		}, nil
+	}
+
+	v := s.last
+	return &v, nil
+}
+
+// BindMQTTController attaches an MQTT-fed virtual controller to a
+// particular name, for driver stations that publish gamepad input
+// over the network (e.g. "driverstation/<field>/gamepad") instead of
+// a local USB joystick.
+func (j *JSController) BindMQTTController(name string, client mqtt.Client, topic string) error {
+	src, err := newMQTTSource(client, topic, j.l.Named(name))
+	if err != nil {
+		return err
+	}
+
+	j.cMutex.Lock()
+	defer j.cMutex.Unlock()
+	j.controllers[name] = src
+
+	j.l.Info("Successfully bound MQTT controller", "fid", name, "topic", topic)
+	j.RegisterProbe(name)
+	j.publish("gamepad.bind", name, map[string]interface{}{"topic": topic})
+	return nil
+}
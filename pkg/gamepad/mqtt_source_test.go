@@ -0,0 +1,43 @@
+package gamepad
+
+import (
+	"testing"
+	"time"
+
+	"github.com/hashicorp/go-hclog"
+)
+
+func TestMQTTSourceReadFresh(t *testing.T) {
+	s := &mqttSource{
+		l:        hclog.NewNullLogger(),
+		last:     Values{AxisLX: 200, ButtonA: true},
+		lastSeen: time.Now(),
+	}
+
+	v, err := s.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if v.AxisLX != 200 || !v.ButtonA {
+		t.Fatalf("Read() = %+v, want last received Values", v)
+	}
+}
+
+func TestMQTTSourceReadStaleFallsBackToNeutral(t *testing.T) {
+	s := &mqttSource{
+		l:        hclog.NewNullLogger(),
+		last:     Values{AxisLX: 255, ButtonA: true},
+		lastSeen: time.Now().Add(-2 * staleAfter),
+	}
+
+	v, err := s.Read()
+	if err != nil {
+		t.Fatalf("Read: %v", err)
+	}
+	if v.AxisLX != axisNeutral || v.AxisLY != axisNeutral || v.AxisRX != axisNeutral || v.AxisRY != axisNeutral {
+		t.Fatalf("Read() on stale source = %+v, want all axes centered at %d", v, axisNeutral)
+	}
+	if v.ButtonA {
+		t.Fatalf("Read() on stale source = %+v, want all buttons released", v)
+	}
+}
@@ -0,0 +1,25 @@
+package gamepad
+
+import (
+	"github.com/BESTRobotics/gizmo/pkg/events"
+	"github.com/hashicorp/go-hclog"
+)
+
+// Option enables variadic option passing to the controller on
+// startup.
+type Option func(*JSController)
+
+// WithLogger sets the logger for the controller.
+func WithLogger(l hclog.Logger) Option {
+	return func(j *JSController) {
+		j.l = l.Named("gamepad")
+	}
+}
+
+// WithEventBus wires the controller up to publish bind/unbind events
+// to bus, so a match replay can show when a controller went away.
+func WithEventBus(bus *events.Bus) Option {
+	return func(j *JSController) {
+		j.events = bus
+	}
+}
@@ -0,0 +1,59 @@
+package gamepad
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BESTRobotics/gizmo/pkg/probe"
+)
+
+// controllerStaleAfter is how long a bound controller can go without
+// a successful GetState before its liveness probe reports it as
+// failed.
+const controllerStaleAfter = 2 * time.Second
+
+// fieldProbe reports the liveness of a single bound controller,
+// local or MQTT-backed, based on how recently GetState last
+// succeeded for it.
+type fieldProbe struct {
+	j     *JSController
+	field string
+}
+
+func (p fieldProbe) Check() (probe.Status, string) {
+	p.j.cMutex.RLock()
+	_, bound := p.j.controllers[p.field]
+	seen, everRead := p.j.lastSeen[p.field]
+	p.j.cMutex.RUnlock()
+
+	if !bound {
+		return probe.StatusFail, fmt.Sprintf("no controller bound for field %s", p.field)
+	}
+	if !everRead {
+		return probe.StatusWarn, fmt.Sprintf("field %s bound but has not reported state yet", p.field)
+	}
+	if age := time.Since(seen); age > controllerStaleAfter {
+		return probe.StatusFail, fmt.Sprintf("field %s stale for %s", p.field, age.Round(time.Millisecond))
+	}
+	return probe.StatusOK, fmt.Sprintf("field %s reporting", p.field)
+}
+
+// RegisterProbe registers a health check for the given field's
+// bound controller under probe name "gamepad-<field>", the first
+// time a controller is bound to that field. The probe reads
+// j.controllers/j.lastSeen fresh on every Check, so it keeps
+// reporting correctly across later unbind/rebind cycles (e.g.
+// swapping a dead joystick, or switching a field from local USB to
+// MQTT mid-event) without needing to re-register; doing so would hit
+// probe.Register's duplicate-name panic. Callers must hold cMutex
+// for writing.
+func (j *JSController) RegisterProbe(field string) {
+	if j.registeredProbes[field] {
+		return
+	}
+	j.registeredProbes[field] = true
+
+	probe.Register("gamepad-"+field, func() probe.Prober {
+		return fieldProbe{j: j, field: field}
+	})
+}
@@ -0,0 +1,58 @@
+package gamepad
+
+import (
+	"github.com/0xcafed00d/joystick"
+)
+
+// Source abstracts over where a gamepad's Values come from, so a
+// JSController can drive a field from a local USB joystick or from a
+// virtual controller fed over MQTT by a remote driver station.
+type Source interface {
+	// Read returns the current Values for this source.
+	// Implementations are responsible for their own staleness/safety
+	// handling; Read should never block waiting on new input.
+	Read() (*Values, error)
+}
+
+// localJSSource reads directly from a USB joystick via the joystick
+// package. This is the original input path.
+type localJSSource struct {
+	js joystick.Joystick
+}
+
+func (s *localJSSource) Read() (*Values, error) {
+	jinfo, err := s.js.Read()
+	if err != nil {
+		return nil, err
+	}
+
+	jvals := Values{
+		AxisLX: mapRange(jinfo.AxisData[0], -32768, 32768, 0, 255),
+		AxisLY: mapRange(jinfo.AxisData[1], -32768, 32768, 0, 255),
+
+		AxisRX: mapRange(jinfo.AxisData[2], -32768, 32768, 0, 255),
+		AxisRY: mapRange(jinfo.AxisData[3], -32768, 32768, 0, 255),
+
+		AxisDX: mapRange(jinfo.AxisData[4], -32768, 32768, 0, 255),
+		AxisDY: mapRange(jinfo.AxisData[5], -32768, 32768, 0, 255),
+
+		ButtonBack:       (jinfo.Buttons & (1 << uint32(8))) != 0,
+		ButtonStart:      (jinfo.Buttons & (1 << uint32(9))) != 0,
+		ButtonLeftStick:  (jinfo.Buttons & (1 << uint32(10))) != 0,
+		ButtonRightStick: (jinfo.Buttons & (1 << uint32(11))) != 0,
+		ButtonX:          (jinfo.Buttons & (1 << uint32(0))) != 0,
+		ButtonY:          (jinfo.Buttons & (1 << uint32(3))) != 0,
+		ButtonA:          (jinfo.Buttons & (1 << uint32(1))) != 0,
+		ButtonB:          (jinfo.Buttons & (1 << uint32(2))) != 0,
+		ButtonLShoulder:  (jinfo.Buttons & (1 << uint32(4))) != 0,
+		ButtonRShoulder:  (jinfo.Buttons & (1 << uint32(5))) != 0,
+		ButtonLT:         (jinfo.Buttons & (1 << uint32(6))) != 0,
+		ButtonRT:         (jinfo.Buttons & (1 << uint32(7))) != 0,
+	}
+
+	return &jvals, nil
+}
+
+func mapRange(x, xMin, xMax, oMin, oMax int) int {
+	return (x-xMin)*(oMax-oMin)/(xMax-xMin) + oMin
+}
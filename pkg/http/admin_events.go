@@ -0,0 +1,102 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/BESTRobotics/gizmo/pkg/events"
+	"github.com/gorilla/websocket"
+)
+
+var eventsUpgrader = websocket.Upgrader{
+	// Admin endpoints are only ever reached from the same driver
+	// station network as the rest of /admin/*, so we don't need the
+	// usual cross-origin caution here.
+	CheckOrigin: func(r *http.Request) bool { return true },
+}
+
+const (
+	// eventsSendBuffer is how many unwritten events a single
+	// websocket client can fall behind by before we start dropping
+	// events for it rather than let it back up further.
+	eventsSendBuffer = 32
+
+	// eventsWriteWait bounds how long a single WriteJSON call may
+	// block a stalled reader before we give up on the connection.
+	eventsWriteWait = 5 * time.Second
+)
+
+// handleAdminEvents serves every buffered event newer than ?since=
+// (a unix timestamp in seconds; defaults to the epoch, i.e.
+// everything currently buffered).
+func (s *Server) handleAdminEvents(w http.ResponseWriter, r *http.Request) {
+	since := time.Time{}
+	if raw := r.URL.Query().Get("since"); raw != "" {
+		secs, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			http.Error(w, "invalid since", http.StatusBadRequest)
+			return
+		}
+		since = time.Unix(secs, 0)
+	}
+
+	json.NewEncoder(w).Encode(s.eventRing.Since(since))
+}
+
+// handleAdminEventsWS upgrades to a WebSocket and streams every
+// event published from this point on, so a dashboard can watch a
+// match unfold live instead of polling /admin/events.
+//
+// Bus.Publish runs every subscriber synchronously while holding its
+// lock (see events.Bus.Publish), so the Subscribe callback below must
+// never block on the network: it only ever hands the event to a
+// buffered channel, which this goroutine drains and writes with a
+// bounded deadline. That way a single stalled browser tab can only
+// ever fall behind or get disconnected — it can never stall
+// Bus.Publish and, with it, every other publisher on the bus
+// including the live robot-telemetry ingest path.
+func (s *Server) handleAdminEventsWS(w http.ResponseWriter, r *http.Request) {
+	conn, err := eventsUpgrader.Upgrade(w, r, nil)
+	if err != nil {
+		s.l.Warn("Failed to upgrade events websocket", "error", err)
+		return
+	}
+	defer conn.Close()
+
+	send := make(chan events.Event, eventsSendBuffer)
+	unsubscribe := s.events.Subscribe(func(ev events.Event) {
+		select {
+		case send <- ev:
+		default:
+			s.l.Warn("Dropping event for slow events websocket client", "seq", ev.Seq)
+		}
+	})
+	defer unsubscribe()
+
+	for ev := range send {
+		conn.SetWriteDeadline(time.Now().Add(eventsWriteWait))
+		if err := conn.WriteJSON(ev); err != nil {
+			return
+		}
+	}
+}
+
+// withAdminEventLogging wraps an admin handler so every call to it
+// is published to the event bus as an "admin.request" event. Route
+// registration wraps each /admin/* handler with this so the history
+// of who-called-what is reconstructable alongside the domain-specific
+// events (map.swap, robot.report, ...).
+func (s *Server) withAdminEventLogging(route string, h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.events != nil {
+			s.events.Publish("admin.request", events.Labels{}, map[string]string{
+				"route":      route,
+				"method":     r.Method,
+				"remoteAddr": r.RemoteAddr,
+			})
+		}
+		h(w, r)
+	}
+}
@@ -0,0 +1,28 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/BESTRobotics/gizmo/pkg/probe"
+)
+
+// handleHealth serves the aggregated result of every registered
+// probe, so field staff can see what's broken without scraping
+// Prometheus.
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(probe.RunAll())
+}
+
+// handleHealthProbe serves the result of a single named probe, e.g.
+// GET /health/mqtt-broker.
+func (s *Server) handleHealthProbe(w http.ResponseWriter, r *http.Request) {
+	name := strings.TrimPrefix(r.URL.Path, "/health/")
+	res, ok := probe.Run(name)
+	if !ok {
+		http.Error(w, "no such probe", http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(res)
+}
@@ -0,0 +1,231 @@
+package http
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/BESTRobotics/gizmo/pkg/events"
+	"github.com/BESTRobotics/gizmo/pkg/probe"
+)
+
+// MapVersion is a single entry in the append-only history of
+// team/quadrant mappings.  Version numbers start at 1 and increase
+// monotonically; there is no version 0, so a Version of 0 means "no
+// mapping has ever been applied".
+type MapVersion struct {
+	Version   int               `json:"version"`
+	Mapping   map[string]string `json:"mapping"`
+	Operator  string            `json:"operator"`
+	Reason    string            `json:"reason"`
+	Timestamp time.Time         `json:"timestamp"`
+}
+
+// mapHistory is an append-only, file-backed log of every mapping
+// that has ever been applied to the field.  It backs the
+// /admin/map/history, /admin/map/version/{n}, and /admin/map/swap
+// endpoints.
+type mapHistory struct {
+	mu       sync.RWMutex
+	versions []MapVersion
+	f        *os.File
+}
+
+// newMapHistory opens (creating if necessary) the append-only log at
+// path and replays it to recover the in-memory history.
+func newMapHistory(path string) (*mapHistory, error) {
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_RDWR|os.O_APPEND, 0644)
+	if err != nil {
+		return nil, err
+	}
+
+	h := &mapHistory{f: f}
+	scn := bufio.NewScanner(f)
+	for scn.Scan() {
+		var v MapVersion
+		if err := json.Unmarshal(scn.Bytes(), &v); err != nil {
+			return nil, fmt.Errorf("corrupt map history at version %d: %w", len(h.versions)+1, err)
+		}
+		h.versions = append(h.versions, v)
+	}
+	if err := scn.Err(); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// Current returns the most recently applied mapping.  If no mapping
+// has ever been applied, Version is 0 and Mapping is empty.
+func (h *mapHistory) Current() MapVersion {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if len(h.versions) == 0 {
+		return MapVersion{Mapping: map[string]string{}}
+	}
+	return h.versions[len(h.versions)-1]
+}
+
+// All returns every version in the history, oldest first.
+func (h *mapHistory) All() []MapVersion {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	out := make([]MapVersion, len(h.versions))
+	copy(out, h.versions)
+	return out
+}
+
+// Get returns the version numbered n, if it exists.
+func (h *mapHistory) Get(n int) (MapVersion, bool) {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	if n < 1 || n > len(h.versions) {
+		return MapVersion{}, false
+	}
+	return h.versions[n-1], true
+}
+
+// Swap appends a new mapping as the next version, but only if from
+// matches the version number of the current mapping.  This is the
+// optimistic-concurrency check that keeps two remap operators from
+// clobbering each other mid-match.  now is injected so callers can
+// keep this deterministic in tests.
+func (h *mapHistory) Swap(from int, mapping map[string]string, operator, reason string, now time.Time) (MapVersion, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	cur := 0
+	if len(h.versions) > 0 {
+		cur = h.versions[len(h.versions)-1].Version
+	}
+	if from != cur {
+		return MapVersion{}, errMapVersionConflict
+	}
+
+	v := MapVersion{
+		Version:   cur + 1,
+		Mapping:   mapping,
+		Operator:  operator,
+		Reason:    reason,
+		Timestamp: now,
+	}
+
+	line, err := json.Marshal(v)
+	if err != nil {
+		return MapVersion{}, err
+	}
+	if _, err := h.f.Write(append(line, '\n')); err != nil {
+		return MapVersion{}, err
+	}
+
+	h.versions = append(h.versions, v)
+	return v, nil
+}
+
+var errMapVersionConflict = fmt.Errorf("current map version does not match")
+
+// WithMapHistory configures the server to persist the versioned
+// team/quadrant mapping history to the append-only log at path.
+func WithMapHistory(path string) Option {
+	return func(s *Server) error {
+		h, err := newMapHistory(path)
+		if err != nil {
+			return err
+		}
+		s.mapHistory = h
+		probe.Register("team-mapping", func() probe.Prober { return mapFreshnessProbe{h: h} })
+		return nil
+	}
+}
+
+// mapFreshnessProbe reports whether the field server has a current
+// team/quadrant mapping, and how long ago it was applied.
+type mapFreshnessProbe struct {
+	h *mapHistory
+}
+
+func (p mapFreshnessProbe) Check() (probe.Status, string) {
+	cur := p.h.Current()
+	if cur.Version == 0 {
+		return probe.StatusWarn, "no mapping has been applied yet"
+	}
+	return probe.StatusOK, fmt.Sprintf("v%d applied %s ago by %s", cur.Version, time.Since(cur.Timestamp).Round(time.Second), cur.Operator)
+}
+
+// handleMapCurrent serves the current mapping version.
+func (s *Server) handleMapCurrent(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(s.mapHistory.Current())
+}
+
+// handleMapHistory serves every mapping version that has ever been
+// applied, oldest first.
+func (s *Server) handleMapHistory(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(s.mapHistory.All())
+}
+
+// handleMapVersion serves a single historical mapping version, named
+// by the trailing path element, e.g. /admin/map/version/3.
+func (s *Server) handleMapVersion(w http.ResponseWriter, r *http.Request) {
+	raw := strings.TrimPrefix(r.URL.Path, "/admin/map/version/")
+	n, err := strconv.Atoi(raw)
+	if err != nil {
+		http.Error(w, "invalid version", http.StatusBadRequest)
+		return
+	}
+
+	v, ok := s.mapHistory.Get(n)
+	if !ok {
+		http.Error(w, "no such version", http.StatusNotFound)
+		return
+	}
+	json.NewEncoder(w).Encode(v)
+}
+
+// handleMapSwap atomically applies a new mapping, rejecting the
+// request with 409 Conflict if the ?from= version doesn't match the
+// server's current version.
+func (s *Server) handleMapSwap(w http.ResponseWriter, r *http.Request) {
+	from, err := strconv.Atoi(r.URL.Query().Get("from"))
+	if err != nil {
+		http.Error(w, "missing or invalid from", http.StatusBadRequest)
+		return
+	}
+
+	var req struct {
+		Mapping  map[string]string `json:"mapping"`
+		Operator string            `json:"operator"`
+		Reason   string            `json:"reason"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, "bad request body", http.StatusBadRequest)
+		return
+	}
+
+	v, err := s.mapHistory.Swap(from, req.Mapping, req.Operator, req.Reason, time.Now())
+	if err == errMapVersionConflict {
+		s.l.Warn("Rejected map swap, version conflict", "from", from, "operator", req.Operator)
+		http.Error(w, "map version conflict, refresh and retry", http.StatusConflict)
+		return
+	}
+	if err != nil {
+		s.l.Error("Failed to record map swap", "from", from, "operator", req.Operator, "error", err)
+		http.Error(w, "failed to record map swap", http.StatusInternalServerError)
+		return
+	}
+
+	s.tlm.SetMapping(v.Mapping)
+
+	if s.events != nil {
+		s.events.Publish("map.swap", events.Labels{}, v)
+	}
+
+	json.NewEncoder(w).Encode(v)
+}
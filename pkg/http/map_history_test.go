@@ -0,0 +1,50 @@
+package http
+
+import (
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestMapHistorySwap(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "map-history.jsonl")
+	h, err := newMapHistory(path)
+	if err != nil {
+		t.Fatalf("newMapHistory: %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+
+	v1, err := h.Swap(0, map[string]string{"red-1": "Q1"}, "alice", "initial map", now)
+	if err != nil {
+		t.Fatalf("Swap from 0: %v", err)
+	}
+	if v1.Version != 1 {
+		t.Fatalf("expected version 1, got %d", v1.Version)
+	}
+
+	if _, err := h.Swap(0, map[string]string{"red-1": "Q2"}, "bob", "stale retry", now); err != errMapVersionConflict {
+		t.Fatalf("expected errMapVersionConflict on stale from, got %v", err)
+	}
+
+	v2, err := h.Swap(1, map[string]string{"red-1": "Q2"}, "bob", "fix quad", now.Add(time.Minute))
+	if err != nil {
+		t.Fatalf("Swap from 1: %v", err)
+	}
+	if v2.Version != 2 {
+		t.Fatalf("expected version 2, got %d", v2.Version)
+	}
+
+	if cur := h.Current(); cur.Version != 2 || cur.Mapping["red-1"] != "Q2" {
+		t.Fatalf("Current() = %+v, want version 2 mapping red-1=Q2", cur)
+	}
+
+	// Reopening the log should replay both versions back in.
+	h2, err := newMapHistory(path)
+	if err != nil {
+		t.Fatalf("reopen newMapHistory: %v", err)
+	}
+	if got := len(h2.All()); got != 2 {
+		t.Fatalf("reopened history has %d versions, want 2", got)
+	}
+}
@@ -1,8 +1,10 @@
 package http
 
 import (
+	"errors"
 	"sync"
 
+	"github.com/BESTRobotics/gizmo/pkg/events"
 	"github.com/hashicorp/go-hclog"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -45,6 +47,39 @@ func WithQuads(q []string) Option {
 	}
 }
 
+// WithEventBus wires the server up to publish admin/remap events to
+// bus, and to serve /admin/events off of it.
+func WithEventBus(bus *events.Bus) Option {
+	return func(s *Server) error {
+		s.events = bus
+		s.eventRing = events.NewRingBuffer(0)
+		bus.Subscribe(s.eventRing.Add)
+		return nil
+	}
+}
+
+// WithEventLog subscribes a file sink to the server's event bus, so
+// every event published during a match — remaps, controller
+// bind/unbind, robot reports, admin calls — is also durably recorded
+// as newline-delimited JSON at path. This is the log "gizmo field
+// replay" reads to re-feed a match through the Prometheus collectors
+// offline; without it the event bus only ever holds what still fits
+// in the in-memory ring buffer. Must be passed after WithEventBus.
+func WithEventLog(path string) Option {
+	return func(s *Server) error {
+		if s.events == nil {
+			return errors.New("http: WithEventLog requires WithEventBus")
+		}
+
+		sink, err := events.NewFileSink(path, s.l.Named("events-filesink"))
+		if err != nil {
+			return err
+		}
+		s.events.Subscribe(sink.Write)
+		return nil
+	}
+}
+
 // WithStartupWG allows a waitgroup to be passed in so the server can
 // notify when its finished with startup tasks to allow a nice message
 // to be printed to the console.
@@ -0,0 +1,13 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+)
+
+// handleCfgQuads serves the quadrants this server was configured
+// with via WithQuads, so "field remap" knows what to prompt for
+// without the list being hardcoded on the client.
+func (s *Server) handleCfgQuads(w http.ResponseWriter, r *http.Request) {
+	json.NewEncoder(w).Encode(s.quads)
+}
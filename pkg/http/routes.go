@@ -0,0 +1,26 @@
+package http
+
+import "net/http"
+
+// Handler builds the mux for the field server, wiring every endpoint
+// this package serves. NewServer uses this as the http.Handler it
+// listens with, so a handler method earns no traffic until it's
+// registered here.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+
+	mux.HandleFunc("/admin/cfg/quads", s.withAdminEventLogging("/admin/cfg/quads", s.handleCfgQuads))
+
+	mux.HandleFunc("/admin/map/current", s.withAdminEventLogging("/admin/map/current", s.handleMapCurrent))
+	mux.HandleFunc("/admin/map/history", s.withAdminEventLogging("/admin/map/history", s.handleMapHistory))
+	mux.HandleFunc("/admin/map/version/", s.withAdminEventLogging("/admin/map/version", s.handleMapVersion))
+	mux.HandleFunc("/admin/map/swap", s.withAdminEventLogging("/admin/map/swap", s.handleMapSwap))
+
+	mux.HandleFunc("/health", s.handleHealth)
+	mux.HandleFunc("/health/", s.handleHealthProbe)
+
+	mux.HandleFunc("/admin/events", s.withAdminEventLogging("/admin/events", s.handleAdminEvents))
+	mux.HandleFunc("/admin/events/ws", s.withAdminEventLogging("/admin/events/ws", s.handleAdminEventsWS))
+
+	return mux
+}
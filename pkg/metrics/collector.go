@@ -0,0 +1,141 @@
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Collector is the single source of truth for every Prometheus
+// metric describing robot telemetry. It used to be duplicated,
+// under inconsistent namespaces and names, between this package and
+// stats; both now build on this type so there is exactly one set of
+// metric names to write alert rules against.
+type Collector struct {
+	RobotRSSI             *prometheus.GaugeVec
+	RobotVBat             *prometheus.GaugeVec
+	RobotPowerBoard       *prometheus.GaugeVec
+	RobotPowerPico        *prometheus.GaugeVec
+	RobotPowerGPIO        *prometheus.GaugeVec
+	RobotPowerBusA        *prometheus.GaugeVec
+	RobotPowerBusB        *prometheus.GaugeVec
+	RobotWatchdogOK       *prometheus.GaugeVec
+	RobotWatchdogLifetime *prometheus.GaugeVec
+
+	// RobotLastReportTimestamp records the unix time of the last
+	// stats report accepted from each robot, so an alert rule can
+	// fire on "robot silent > 5s".
+	RobotLastReportTimestamp *prometheus.GaugeVec
+
+	// ReportParseErrors counts stats reports that failed to decode,
+	// so an alert rule can fire on "parse errors climbing".
+	ReportParseErrors prometheus.Counter
+}
+
+// NewCollector builds a Collector and registers every metric it owns
+// against reg.
+func NewCollector(reg *prometheus.Registry) *Collector {
+	c := &Collector{
+		RobotRSSI: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "rssi",
+			Help:      "WiFi signal strength as measured by the system processor.",
+		}, []string{"team"}),
+
+		RobotVBat: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "battery_voltage",
+			Help:      "Robot Battery volage.",
+		}, []string{"team"}),
+
+		RobotPowerBoard: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "power_board",
+			Help:      "General logic power available.",
+		}, []string{"team"}),
+
+		RobotPowerPico: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "power_pico",
+			Help:      "Pico power supply available.",
+		}, []string{"team"}),
+
+		RobotPowerGPIO: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "power_gpio",
+			Help:      "GPIO power supply available.",
+		}, []string{"team"}),
+
+		RobotPowerBusA: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "power_bus_a",
+			Help:      "Motor Bus A power available.",
+		}, []string{"team"}),
+
+		RobotPowerBusB: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "power_bus_b",
+			Help:      "Motor Bus B power available.",
+		}, []string{"team"}),
+
+		RobotWatchdogOK: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "watchdog_ok",
+			Help:      "Watchdog has been fed and is alive.",
+		}, []string{"team"}),
+
+		RobotWatchdogLifetime: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "watchdog_remaining_milliseconds",
+			Help:      "Watchdog lifetime remaining since last feed.",
+		}, []string{"team"}),
+
+		RobotLastReportTimestamp: prometheus.NewGaugeVec(prometheus.GaugeOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "last_report_timestamp_seconds",
+			Help:      "Unix timestamp of the last accepted stats report from this robot.",
+		}, []string{"team"}),
+
+		ReportParseErrors: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "best",
+			Subsystem: "robot",
+			Name:      "report_parse_errors_total",
+			Help:      "Count of stats reports that failed to decode as JSON.",
+		}),
+	}
+
+	reg.MustRegister(c.RobotRSSI)
+	reg.MustRegister(c.RobotVBat)
+	reg.MustRegister(c.RobotPowerBoard)
+	reg.MustRegister(c.RobotPowerPico)
+	reg.MustRegister(c.RobotPowerGPIO)
+	reg.MustRegister(c.RobotPowerBusA)
+	reg.MustRegister(c.RobotPowerBusB)
+	reg.MustRegister(c.RobotWatchdogOK)
+	reg.MustRegister(c.RobotWatchdogLifetime)
+	reg.MustRegister(c.RobotLastReportTimestamp)
+	reg.MustRegister(c.ReportParseErrors)
+
+	return c
+}
+
+// Reset clears every per-robot gauge, leaving counters (like
+// ReportParseErrors) untouched.
+func (c *Collector) Reset() {
+	c.RobotRSSI.Reset()
+	c.RobotVBat.Reset()
+	c.RobotPowerBoard.Reset()
+	c.RobotPowerPico.Reset()
+	c.RobotPowerGPIO.Reset()
+	c.RobotPowerBusA.Reset()
+	c.RobotPowerBusB.Reset()
+	c.RobotWatchdogOK.Reset()
+	c.RobotWatchdogLifetime.Reset()
+	c.RobotLastReportTimestamp.Reset()
+}
@@ -6,6 +6,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/BESTRobotics/gizmo/pkg/events"
 	"github.com/cenkalti/backoff/v4"
 	mqtt "github.com/eclipse/paho.mqtt.golang"
 	"github.com/hashicorp/go-hclog"
@@ -14,85 +15,15 @@ import (
 
 // New returns an initialized instance of the metrics system.
 func New(opts ...Option) *Metrics {
+	reg := prometheus.NewRegistry()
 	x := &Metrics{
-		l:      hclog.NewNullLogger(),
-		r:      prometheus.NewRegistry(),
-		broker: "mqtt://127.0.0.1:1883",
-
-		robotRSSI: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "rssi",
-			Help:      "WiFi signal strength as measured by the system processor.",
-		}, []string{"team"}),
-
-		robotVBat: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "battery_voltage",
-			Help:      "Robot Battery volage.",
-		}, []string{"team"}),
-
-		robotPowerBoard: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "power_board",
-			Help:      "General logic power available.",
-		}, []string{"team"}),
-
-		robotPowerPico: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "power_pico",
-			Help:      "Pico power supply available.",
-		}, []string{"team"}),
-
-		robotPowerGPIO: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "power_gpio",
-			Help:      "GPIO power supply available.",
-		}, []string{"team"}),
-
-		robotPowerBusA: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "power_bus_a",
-			Help:      "Motor Bus A power available.",
-		}, []string{"team"}),
-
-		robotPowerBusB: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "power_bus_b",
-			Help:      "Motor Bus B power available.",
-		}, []string{"team"}),
-
-		robotWatchdogOK: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "watchdog_ok",
-			Help:      "Watchdog has been fed and is alive.",
-		}, []string{"team"}),
-
-		robotWatchdogLifetime: prometheus.NewGaugeVec(prometheus.GaugeOpts{
-			Namespace: "best",
-			Subsystem: "robot",
-			Name:      "watchdog_remaining_milliseconds",
-			Help:      "Watchdog lifetime remaining since last feed.",
-		}, []string{"team"}),
+		l:        hclog.NewNullLogger(),
+		r:        reg,
+		c:        NewCollector(reg),
+		broker:   "mqtt://127.0.0.1:1883",
+		teamSeen: make(map[string]time.Time),
 	}
 
-	x.r.MustRegister(x.robotRSSI)
-	x.r.MustRegister(x.robotVBat)
-	x.r.MustRegister(x.robotPowerBoard)
-	x.r.MustRegister(x.robotPowerPico)
-	x.r.MustRegister(x.robotPowerGPIO)
-	x.r.MustRegister(x.robotPowerBusA)
-	x.r.MustRegister(x.robotPowerBusB)
-	x.r.MustRegister(x.robotWatchdogOK)
-	x.r.MustRegister(x.robotWatchdogLifetime)
-
 	for _, o := range opts {
 		o(x)
 	}
@@ -109,15 +40,7 @@ func (m *Metrics) Registry() *prometheus.Registry {
 // ResetRobotMetrics clears all metrics associated with robots and
 // resets the built-in exporter to a clean state.
 func (m *Metrics) ResetRobotMetrics() {
-	m.robotRSSI.Reset()
-	m.robotVBat.Reset()
-	m.robotPowerBoard.Reset()
-	m.robotPowerPico.Reset()
-	m.robotPowerGPIO.Reset()
-	m.robotPowerBusA.Reset()
-	m.robotPowerBusB.Reset()
-	m.robotWatchdogOK.Reset()
-	m.robotWatchdogLifetime.Reset()
+	m.c.Reset()
 }
 
 func (m *Metrics) mqttCallback(c mqtt.Client, msg mqtt.Message) {
@@ -126,18 +49,30 @@ func (m *Metrics) mqttCallback(c mqtt.Client, msg mqtt.Message) {
 	var stats report
 	if err := json.Unmarshal(msg.Payload(), &stats); err != nil {
 		m.l.Warn("Bad stats report", "team", teamNum, "error", err)
+		m.c.ReportParseErrors.Inc()
+		return
 	}
 
-	m.robotRSSI.With(prometheus.Labels{"team": teamNum}).Set(float64(stats.RSSI))
-	m.robotVBat.With(prometheus.Labels{"team": teamNum}).Set(float64(stats.VBat))
-	m.robotWatchdogLifetime.With(prometheus.Labels{"team": teamNum}).Set(float64(stats.WatchdogRemaining))
+	m.c.RobotRSSI.With(prometheus.Labels{"team": teamNum}).Set(float64(stats.RSSI))
+	m.c.RobotVBat.With(prometheus.Labels{"team": teamNum}).Set(float64(stats.VBat))
+	m.c.RobotWatchdogLifetime.With(prometheus.Labels{"team": teamNum}).Set(float64(stats.WatchdogRemaining))
+
+	m.c.RobotPowerBoard.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrBoard))
+	m.c.RobotPowerPico.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrPico))
+	m.c.RobotPowerGPIO.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrGPIO))
+	m.c.RobotPowerBusA.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrMainA))
+	m.c.RobotPowerBusB.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrMainB))
+	m.c.RobotWatchdogOK.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.WatchdogOK))
+
+	m.c.RobotLastReportTimestamp.With(prometheus.Labels{"team": teamNum}).Set(float64(time.Now().Unix()))
 
-	m.robotPowerBoard.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrBoard))
-	m.robotPowerPico.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrPico))
-	m.robotPowerGPIO.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrGPIO))
-	m.robotPowerBusA.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrMainA))
-	m.robotPowerBusB.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.PwrMainB))
-	m.robotWatchdogOK.With(prometheus.Labels{"team": teamNum}).Set(fCast(stats.WatchdogOK))
+	m.teamMu.Lock()
+	m.teamSeen[teamNum] = time.Now()
+	m.teamMu.Unlock()
+
+	if m.events != nil {
+		m.events.Publish("robot.report", events.Labels{Team: teamNum}, stats)
+	}
 }
 
 // MQTTInit connects to the mqtt server and listens for metrics.
@@ -155,6 +90,8 @@ func (m *Metrics) MQTTInit(wg *sync.WaitGroup) error {
 		m.l.Error("Error connecting to broker", "error", tok.Error())
 		return tok.Error()
 	}
+	m.client = client
+	m.registerProbes()
 	m.l.Info("Connected to broker")
 
 	subFunc := func() error {
@@ -0,0 +1,12 @@
+package metrics
+
+import "github.com/BESTRobotics/gizmo/pkg/events"
+
+// WithEventBus wires the metrics listener up to publish a
+// "robot.report" event for every stats report it processes, so a
+// match replay can show exactly what telemetry each robot sent.
+func WithEventBus(bus *events.Bus) Option {
+	return func(m *Metrics) {
+		m.events = bus
+	}
+}
@@ -0,0 +1,59 @@
+package metrics
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/BESTRobotics/gizmo/pkg/probe"
+)
+
+// robotSilentAfter is how long a robot can go without a stats report
+// before the watchdog probe reports it as failed.
+const robotSilentAfter = 5 * time.Second
+
+// mqttProbe reports whether this Metrics instance is currently
+// connected to its MQTT broker.
+type mqttProbe struct {
+	m *Metrics
+}
+
+func (p mqttProbe) Check() (probe.Status, string) {
+	if p.m.client == nil || !p.m.client.IsConnected() {
+		return probe.StatusFail, fmt.Sprintf("not connected to %s", p.m.broker)
+	}
+	return probe.StatusOK, fmt.Sprintf("connected to %s", p.m.broker)
+}
+
+// watchdogProbe reports whether every robot that has ever reported
+// in is still reporting recently enough.
+type watchdogProbe struct {
+	m *Metrics
+}
+
+func (p watchdogProbe) Check() (probe.Status, string) {
+	p.m.teamMu.RLock()
+	defer p.m.teamMu.RUnlock()
+
+	if len(p.m.teamSeen) == 0 {
+		return probe.StatusWarn, "no robots have reported yet"
+	}
+
+	silent := []string{}
+	for team, seen := range p.m.teamSeen {
+		if time.Since(seen) > robotSilentAfter {
+			silent = append(silent, team)
+		}
+	}
+	if len(silent) > 0 {
+		return probe.StatusFail, fmt.Sprintf("silent robots: %v", silent)
+	}
+	return probe.StatusOK, fmt.Sprintf("%d robot(s) reporting", len(p.m.teamSeen))
+}
+
+// registerProbes registers this instance's health checks with the
+// probe registry. It is called once MQTTInit has a connected client
+// to inspect.
+func (m *Metrics) registerProbes() {
+	probe.Register("mqtt-broker", func() probe.Prober { return mqttProbe{m: m} })
+	probe.Register("robot-watchdog", func() probe.Prober { return watchdogProbe{m: m} })
+}
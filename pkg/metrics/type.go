@@ -1,6 +1,11 @@
 package metrics
 
 import (
+	"sync"
+	"time"
+
+	"github.com/BESTRobotics/gizmo/pkg/events"
+	mqtt "github.com/eclipse/paho.mqtt.golang"
 	"github.com/hashicorp/go-hclog"
 	"github.com/prometheus/client_golang/prometheus"
 )
@@ -10,17 +15,13 @@ type Metrics struct {
 	l      hclog.Logger
 	broker string
 
-	r *prometheus.Registry
+	r      *prometheus.Registry
+	c      *Collector
+	client mqtt.Client
+	events *events.Bus
 
-	robotRSSI             *prometheus.GaugeVec
-	robotVBat             *prometheus.GaugeVec
-	robotPowerBoard       *prometheus.GaugeVec
-	robotPowerPico        *prometheus.GaugeVec
-	robotPowerGPIO        *prometheus.GaugeVec
-	robotPowerBusA        *prometheus.GaugeVec
-	robotPowerBusB        *prometheus.GaugeVec
-	robotWatchdogOK       *prometheus.GaugeVec
-	robotWatchdogLifetime *prometheus.GaugeVec
+	teamMu   sync.RWMutex
+	teamSeen map[string]time.Time
 }
 
 type report struct {
@@ -0,0 +1,116 @@
+// Package probe provides a pluggable registry of field/robot health
+// checks. Individual probes self-register from their owning package
+// when the component they check is set up (not from init(), since
+// each probe needs that component's instance), much like exporter
+// collectors, so adding a new health check doesn't require touching
+// the probe package itself.
+package probe
+
+import (
+	"sync"
+	"time"
+)
+
+// Status is the result of running a single probe.
+type Status string
+
+const (
+	// StatusOK means the probe ran and found nothing wrong.
+	StatusOK Status = "ok"
+	// StatusWarn means the probe found something worth a look, but
+	// not serious enough to call the check failed.
+	StatusWarn Status = "warn"
+	// StatusFail means the probe found a real problem.
+	StatusFail Status = "fail"
+)
+
+// Result is the outcome of running a probe, ready to be serialized
+// over /health.
+type Result struct {
+	Name        string    `json:"name"`
+	Status      Status    `json:"status"`
+	Detail      string    `json:"detail"`
+	LastChecked time.Time `json:"last_checked"`
+}
+
+// Prober is a single health check.
+type Prober interface {
+	// Check runs the health check and returns its current result.
+	// LastChecked is filled in by the registry, not the Prober.
+	Check() (status Status, detail string)
+}
+
+// Factory constructs a Prober. Register only stores the factory; it
+// is called again on every Run, so each /health request checks with
+// a freshly built Prober. Concurrent requests call it concurrently.
+type Factory func() Prober
+
+var (
+	mu        sync.RWMutex
+	factories = map[string]Factory{}
+)
+
+// Register adds a probe to the registry under name. Owning packages
+// call it when the component the probe checks is set up, mirroring
+// how Prometheus collectors self-register. Register panics if name is
+// already registered, since that indicates two probes stepping on
+// each other's health-check output.
+func Register(name string, f Factory) {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, exists := factories[name]; exists {
+		panic("probe: duplicate registration for " + name)
+	}
+	factories[name] = f
+}
+
+// Names returns every registered probe name.
+func Names() []string {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	names := make([]string, 0, len(factories))
+	for n := range factories {
+		names = append(names, n)
+	}
+	return names
+}
+
+// Run executes a single named probe and returns its Result. The
+// second return value is false if no probe is registered under
+// name.
+func Run(name string) (Result, bool) {
+	mu.RLock()
+	f, ok := factories[name]
+	mu.RUnlock()
+	if !ok {
+		return Result{}, false
+	}
+
+	status, detail := f().Check()
+	return Result{
+		Name:        name,
+		Status:      status,
+		Detail:      detail,
+		LastChecked: time.Now(),
+	}, true
+}
+
+// RunAll executes every registered probe and returns their Results,
+// keyed by probe name.
+func RunAll() map[string]Result {
+	mu.RLock()
+	names := make([]string, 0, len(factories))
+	for n := range factories {
+		names = append(names, n)
+	}
+	mu.RUnlock()
+
+	out := make(map[string]Result, len(names))
+	for _, n := range names {
+		res, _ := Run(n)
+		out[n] = res
+	}
+	return out
+}